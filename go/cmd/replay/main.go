@@ -3,11 +3,21 @@
 package main
 
 import (
+	"crypto/ed25519"
 	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -52,6 +62,12 @@ type EvidenceExport struct {
 	LastBlobHash  string            `json:"last_blob_hash"`
 	TimeRange     map[string]string `json:"time_range"`
 	Blobs         []NetFlowBlob     `json:"blobs"`
+
+	// Optional detached-signature fields. When absent, verify falls back to
+	// a sidecar "<export>.sig" file alongside the export.
+	Signature          string `json:"signature,omitempty"`
+	SignerKeyID        string `json:"signer_key_id,omitempty"`
+	SignatureAlgorithm string `json:"signature_algorithm,omitempty"`
 }
 
 // ANSI color codes
@@ -82,7 +98,64 @@ func main() {
 			fmt.Println("Usage: zantoras-replay verify <evidence-export.json>")
 			os.Exit(1)
 		}
-		verifyEvidence(os.Args[2])
+		opts := parseVerifyFlags(os.Args[3:])
+		verifyEvidence(os.Args[2], opts)
+	case "repair":
+		if len(os.Args) < 3 {
+			fmt.Printf("%s%sError:%s Missing file path\n", colorBold, colorRed, colorReset)
+			fmt.Println("Usage: zantoras-replay repair <evidence-export.json> [--repair-byte] [--parallel N]")
+			os.Exit(1)
+		}
+		opts := parseVerifyFlags(os.Args[3:])
+		repairEvidence(os.Args[2], opts)
+	case "extract":
+		if len(os.Args) < 3 {
+			fmt.Printf("%s%sError:%s Missing file path\n", colorBold, colorRed, colorReset)
+			fmt.Println("Usage: zantoras-replay extract <export.json> [--blob-id=... | --range=start:end | --anomalies-only] --out ./dir")
+			os.Exit(1)
+		}
+		opts := parseExtractFlags(os.Args[3:])
+		extractEvidence(os.Args[2], opts)
+	case "dump":
+		if len(os.Args) < 3 {
+			fmt.Printf("%s%sError:%s Missing file path\n", colorBold, colorRed, colorReset)
+			fmt.Println("Usage: zantoras-replay dump <export.json> [records|blobs|chain|all]")
+			os.Exit(1)
+		}
+		mode := "all"
+		if len(os.Args) >= 4 {
+			mode = os.Args[3]
+		}
+		dumpEvidence(os.Args[2], mode)
+	case "keys":
+		if len(os.Args) < 3 {
+			fmt.Printf("%s%sError:%s Missing keys subcommand\n", colorBold, colorRed, colorReset)
+			fmt.Println("Usage: zantoras-replay keys fingerprint <pubkey-file> | add <key-id> <pubkey-file> | remove <key-id>")
+			os.Exit(1)
+		}
+		switch os.Args[2] {
+		case "fingerprint":
+			if len(os.Args) < 4 {
+				fmt.Printf("%s%sError:%s Missing pubkey file\n", colorBold, colorRed, colorReset)
+				os.Exit(1)
+			}
+			keysFingerprint(os.Args[3])
+		case "add":
+			if len(os.Args) < 5 {
+				fmt.Printf("%s%sError:%s Usage: zantoras-replay keys add <key-id> <pubkey-file>\n", colorBold, colorRed, colorReset)
+				os.Exit(1)
+			}
+			keysAdd(os.Args[3], os.Args[4])
+		case "remove":
+			if len(os.Args) < 4 {
+				fmt.Printf("%s%sError:%s Usage: zantoras-replay keys remove <key-id>\n", colorBold, colorRed, colorReset)
+				os.Exit(1)
+			}
+			keysRemove(os.Args[3])
+		default:
+			fmt.Printf("%s%sError:%s Unknown keys subcommand '%s'\n", colorBold, colorRed, colorReset, os.Args[2])
+			os.Exit(1)
+		}
 	case "version", "--version", "-v":
 		printVersion()
 	case "help", "--help", "-h":
@@ -94,6 +167,70 @@ func main() {
 	}
 }
 
+// verifyOptions holds the flags shared by the verify and repair subcommands.
+type verifyOptions struct {
+	tryRepair  bool
+	repairByte bool
+	parallel   int
+	workers    int
+	progress   bool
+	failFast   bool
+	trustRoot  string
+}
+
+// parseVerifyFlags does a minimal manual scan of the remaining CLI args.
+// The tool intentionally avoids the "flag" package so that flags can be
+// interleaved freely after the export path.
+func parseVerifyFlags(args []string) verifyOptions {
+	opts := verifyOptions{parallel: 1}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--try-repair":
+			opts.tryRepair = true
+		case arg == "--repair-byte":
+			opts.repairByte = true
+		case arg == "--progress":
+			opts.progress = true
+		case arg == "--fail-fast":
+			opts.failFast = true
+		case arg == "--parallel":
+			if i+1 < len(args) {
+				i++
+				if n, err := strconv.Atoi(args[i]); err == nil && n > 0 {
+					opts.parallel = n
+				}
+			}
+		case strings.HasPrefix(arg, "--parallel="):
+			if n, err := strconv.Atoi(strings.TrimPrefix(arg, "--parallel=")); err == nil && n > 0 {
+				opts.parallel = n
+			}
+		case arg == "--workers":
+			if i+1 < len(args) {
+				i++
+				if n, err := strconv.Atoi(args[i]); err == nil && n > 0 {
+					opts.workers = n
+				}
+			}
+		case strings.HasPrefix(arg, "--workers="):
+			if n, err := strconv.Atoi(strings.TrimPrefix(arg, "--workers=")); err == nil && n > 0 {
+				opts.workers = n
+			}
+		case arg == "--trust-root":
+			if i+1 < len(args) {
+				i++
+				opts.trustRoot = args[i]
+			}
+		case strings.HasPrefix(arg, "--trust-root="):
+			opts.trustRoot = strings.TrimPrefix(arg, "--trust-root=")
+		}
+	}
+	if opts.workers < 1 {
+		opts.workers = runtime.NumCPU()
+	}
+	return opts
+}
+
 func printVersion() {
 	fmt.Printf("%s%sZantoras Evidence Replay Engine%s\n", colorBold, colorCyan, colorReset)
 	fmt.Printf("Version: %s\n", Version)
@@ -104,108 +241,108 @@ func printUsage() {
 	fmt.Printf("\n%s%sZantoras Evidence Replay Engine%s\n", colorBold, colorCyan, colorReset)
 	fmt.Printf("Auditor tool to verify evidence chain integrity\n\n")
 	fmt.Printf("%sUsage:%s\n", colorBold, colorReset)
-	fmt.Printf("  zantoras-replay verify <evidence-export.json>  Import and verify evidence chain\n")
+	fmt.Printf("  zantoras-replay verify <export.json> [--try-repair] [--repair-byte] [--workers N] [--progress] [--fail-fast] [--trust-root <pubkeys.json|dir>]\n")
+	fmt.Printf("                                                  Stream and verify evidence chain integrity\n")
+	fmt.Printf("  zantoras-replay repair <export.json> [--repair-byte] [--parallel N]\n")
+	fmt.Printf("                                                  Probe hash-mismatched blobs for bit rot\n")
+	fmt.Printf("  zantoras-replay extract <export.json> [--blob-id=... | --range=s:e | --anomalies-only] --out ./dir\n")
+	fmt.Printf("                                                  Write selected blobs + manifest.json to a directory\n")
+	fmt.Printf("  zantoras-replay dump <export.json> [records|blobs|chain|all]\n")
+	fmt.Printf("                                                  Emit NDJSON for piping into jq/Zeek/a SIEM\n")
+	fmt.Printf("  zantoras-replay keys fingerprint <pubkey-file>\n")
+	fmt.Printf("                                                  Print an Ed25519 public key's fingerprint\n")
+	fmt.Printf("  zantoras-replay keys add <key-id> <pubkey-file>\n")
+	fmt.Printf("                                                  Trust a public key in the local trust store\n")
+	fmt.Printf("  zantoras-replay keys remove <key-id>\n")
+	fmt.Printf("                                                  Remove a key from the local trust store\n")
 	fmt.Printf("  zantoras-replay version                        Show version\n")
 	fmt.Printf("  zantoras-replay help                           Show this help\n\n")
 	fmt.Printf("%sExamples:%s\n", colorBold, colorReset)
 	fmt.Printf("  zantoras-replay verify evidence-export-2026-02-03.json\n")
-	fmt.Printf("  zantoras-replay verify ./exports/chain-backup.json\n\n")
+	fmt.Printf("  zantoras-replay verify ./exports/chain-backup.json --try-repair\n")
+	fmt.Printf("  zantoras-replay verify ./exports/chain-backup.json --trust-root ./trusted-keys.json\n")
+	fmt.Printf("  zantoras-replay repair ./exports/chain-backup.json --repair-byte --parallel 8\n")
+	fmt.Printf("  zantoras-replay extract ./exports/chain-backup.json --anomalies-only --out ./flagged\n")
+	fmt.Printf("  zantoras-replay dump ./exports/chain-backup.json records | jq '.src_ip'\n")
+	fmt.Printf("  zantoras-replay keys add auditor-2026 ./auditor-2026.pub\n\n")
 }
 
-func verifyEvidence(filePath string) {
+func verifyEvidence(filePath string, opts verifyOptions) {
 	fmt.Printf("\n%s%s╔════════════════════════════════════════════════════════════╗%s\n", colorBold, colorCyan, colorReset)
 	fmt.Printf("%s%s║       ZANTORAS EVIDENCE CHAIN VERIFICATION                 ║%s\n", colorBold, colorCyan, colorReset)
 	fmt.Printf("%s%s╚════════════════════════════════════════════════════════════╝%s\n\n", colorBold, colorCyan, colorReset)
 
-	// Read the file
-	fmt.Printf("%s[1/4]%s Loading evidence file...\n", colorYellow, colorReset)
-	data, err := os.ReadFile(filePath)
+	// Open the file for streaming - the export is never fully buffered in memory.
+	fmt.Printf("%s[1/5]%s Opening evidence file...\n", colorYellow, colorReset)
+	file, err := os.Open(filePath)
 	if err != nil {
-		fmt.Printf("  %s✗%s Failed to read file: %v\n\n", colorRed, colorReset, err)
+		fmt.Printf("  %s✗%s Failed to open file: %v\n\n", colorRed, colorReset, err)
 		printVerificationFailed()
 		os.Exit(1)
 	}
-	fmt.Printf("  %s✓%s File loaded: %s (%d bytes)\n\n", colorGreen, colorReset, filePath, len(data))
-
-	// Parse JSON
-	fmt.Printf("%s[2/4]%s Parsing evidence export...\n", colorYellow, colorReset)
-	var export EvidenceExport
-	if err := json.Unmarshal(data, &export); err != nil {
-		fmt.Printf("  %s✗%s Failed to parse JSON: %v\n\n", colorRed, colorReset, err)
-		printVerificationFailed()
-		os.Exit(1)
+	defer file.Close()
+	if info, err := file.Stat(); err == nil {
+		fmt.Printf("  %s✓%s File opened: %s (%d bytes)\n\n", colorGreen, colorReset, filePath, info.Size())
+	} else {
+		fmt.Printf("  %s✓%s File opened: %s\n\n", colorGreen, colorReset, filePath)
 	}
-	fmt.Printf("  %s✓%s Export parsed successfully\n", colorGreen, colorReset)
-	fmt.Printf("      Version:     %s\n", export.Version)
-	fmt.Printf("      Exported At: %s\n", export.ExportedAt)
-	fmt.Printf("      Exported By: %s\n", export.ExportedBy)
-	fmt.Printf("      Blob Count:  %d\n", export.BlobCount)
-	if export.TimeRange != nil {
-		fmt.Printf("      Time Range:  %s to %s\n", export.TimeRange["start"], export.TimeRange["end"])
+
+	// Parse the header and start streaming blobs. stop lets --fail-fast
+	// reach back into the decode goroutine below and abort the file walk,
+	// not just the hashing.
+	fmt.Printf("%s[2/5]%s Parsing evidence header...\n", colorYellow, colorReset)
+	stop := newStopSignal()
+	dec := json.NewDecoder(file)
+	header, blobsCh, decodeErrCh := streamExportHeader(dec, stop)
+	fmt.Printf("  %s✓%s Header parsed, streaming blobs\n", colorGreen, colorReset)
+	fmt.Printf("      Version:     %s\n", header.Version)
+	fmt.Printf("      Exported At: %s\n", header.ExportedAt)
+	fmt.Printf("      Exported By: %s\n", header.ExportedBy)
+	fmt.Printf("      Blob Count:  %d\n", header.BlobCount)
+	if header.TimeRange != nil {
+		fmt.Printf("      Time Range:  %s to %s\n", header.TimeRange["start"], header.TimeRange["end"])
 	}
+	fmt.Printf("      Workers:     %d\n", opts.workers)
 	fmt.Println()
 
-	// Verify individual blob hashes
-	fmt.Printf("%s[3/4]%s Verifying blob hashes...\n", colorYellow, colorReset)
-	blobHashErrors := 0
-	chainErrors := 0
+	// Verify individual blob hashes + chain linkage, streaming + parallel.
+	fmt.Printf("%s[3/5]%s Verifying blob hashes (%d workers)...\n", colorYellow, colorReset, opts.workers)
 
-	for i, blob := range export.Blobs {
-		// Recompute the hash
-		hashInput := fmt.Sprintf("%s|%s|%d|%d|%s|%d|%d|%d|%s",
-			blob.Record.SrcIP, blob.Record.DstIP, blob.Record.SrcPort, blob.Record.DstPort,
-			blob.Record.Protocol, blob.Record.Timestamp, blob.Record.BytesSent, blob.Record.PacketCount,
-			blob.PreviousHash)
-		hashBytes := sha256.Sum256([]byte(hashInput))
-		computedHash := fmt.Sprintf("%x", hashBytes)
-
-		if computedHash != blob.Hash {
-			blobHashErrors++
-			if blobHashErrors <= 3 {
-				fmt.Printf("  %s✗%s Blob %d hash mismatch:\n", colorRed, colorReset, i+1)
-				fmt.Printf("      Expected: %s\n", blob.Hash[:32]+"...")
-				fmt.Printf("      Computed: %s\n", computedHash[:32]+"...")
-			}
-		}
+	summary := streamVerifyBlobs(blobsCh, opts, stop)
 
-		// Verify chain linkage (skip first blob)
-		if i > 0 {
-			expectedPrevHash := export.Blobs[i-1].Hash
-			if blob.PreviousHash != expectedPrevHash {
-				chainErrors++
-				if chainErrors <= 3 {
-					fmt.Printf("  %s✗%s Blob %d chain break:\n", colorRed, colorReset, i+1)
-					fmt.Printf("      Expected prev: %s\n", expectedPrevHash[:32]+"...")
-					fmt.Printf("      Actual prev:   %s\n", blob.PreviousHash[:32]+"...")
-				}
-			}
-		}
+	if err := <-decodeErrCh; err != nil && !errors.Is(err, errFailFastAbort) {
+		fmt.Printf("  %s✗%s Failed to parse JSON: %v\n\n", colorRed, colorReset, err)
+		printVerificationFailed()
+		os.Exit(1)
+	} else if errors.Is(err, errFailFastAbort) {
+		// The decode goroutine itself bailed out mid-file, as opposed to
+		// stop.Requested() being true merely because fail-fast fired after
+		// decode had already reached the end of the export on its own -
+		// only this case means header fields are actually incomplete.
+		fmt.Printf("  %s○%s --fail-fast stopped the file walk early - blob count, chain\n", colorYellow, colorReset)
+		fmt.Printf("      hash, and any header fields serialized after \"blobs\" (e.g. a\n")
+		fmt.Printf("      trailing signature block) may be incomplete below.\n\n")
 	}
 
-	if blobHashErrors == 0 {
-		fmt.Printf("  %s✓%s All %d blob hashes verified\n", colorGreen, colorReset, len(export.Blobs))
+	if summary.blobHashErrors == 0 {
+		fmt.Printf("  %s✓%s All %d blob hashes verified\n", colorGreen, colorReset, summary.blobsVerified)
 	} else {
-		fmt.Printf("  %s✗%s %d/%d blobs have hash errors\n", colorRed, colorReset, blobHashErrors, len(export.Blobs))
+		fmt.Printf("  %s✗%s %d/%d blobs have hash errors\n", colorRed, colorReset, summary.blobHashErrors, summary.blobsVerified)
 	}
 
-	if chainErrors == 0 {
+	if summary.chainErrors == 0 {
 		fmt.Printf("  %s✓%s Chain linkage intact\n\n", colorGreen, colorReset)
 	} else {
-		fmt.Printf("  %s✗%s %d chain breaks detected\n\n", colorRed, colorReset, chainErrors)
+		fmt.Printf("  %s✗%s %d chain breaks detected\n\n", colorRed, colorReset, summary.chainErrors)
 	}
 
-	// Verify chain hash
-	fmt.Printf("%s[4/4]%s Verifying chain hash...\n", colorYellow, colorReset)
-	var chainHashInput strings.Builder
-	for _, blob := range export.Blobs {
-		chainHashInput.WriteString(blob.Hash)
-	}
-	chainHashBytes := sha256.Sum256([]byte(chainHashInput.String()))
-	computedChainHash := fmt.Sprintf("%x", chainHashBytes)
+	// Verify chain hash (folded in incrementally as blobs arrived, in order)
+	fmt.Printf("%s[4/5]%s Verifying chain hash...\n", colorYellow, colorReset)
+	computedChainHash := fmt.Sprintf("%x", summary.chainHasher.Sum(nil))
 
-	chainHashMatch := computedChainHash == export.ChainHash
+	chainHashMatch := computedChainHash == header.ChainHash
 
-	fmt.Printf("      Stored Chain Hash:   %s\n", export.ChainHash[:32]+"...")
+	fmt.Printf("      Stored Chain Hash:   %s\n", header.ChainHash[:32]+"...")
 	fmt.Printf("      Computed Chain Hash: %s\n", computedChainHash[:32]+"...")
 
 	if chainHashMatch {
@@ -214,16 +351,356 @@ func verifyEvidence(filePath string) {
 		fmt.Printf("  %s✗%s Chain hash mismatch!\n\n", colorRed, colorReset)
 	}
 
+	// Check the detached signature, if any. A matching hash chain only
+	// proves internal consistency - the signature is what ties the export to
+	// a specific signer and rules out a fabricated chain.
+	fmt.Printf("%s[5/5]%s Checking signature...\n", colorYellow, colorReset)
+	if opts.trustRoot != "" {
+		fmt.Printf("      (re-reading the full export to verify the signature - this step is\n")
+		fmt.Printf("      O(export size), not streamed like steps 3/4)\n")
+	}
+	sig := checkSignature(filePath, header, opts.trustRoot)
+	printSignatureCheck(sig)
+
 	// Final verdict
-	printVerificationResult(blobHashErrors, chainErrors, chainHashMatch, export)
+	overallOK := summary.blobHashErrors == 0 && summary.chainErrors == 0 && chainHashMatch
+	if opts.trustRoot != "" {
+		overallOK = overallOK && sig.verified
+	}
+	printVerificationResult(summary.blobHashErrors, summary.chainErrors, chainHashMatch, header.ChainHash, summary.blobsVerified, sig, overallOK)
 }
 
-func printVerificationResult(blobErrors, chainErrors int, chainHashMatch bool, export EvidenceExport) {
+// decodeHeaderField decodes one known scalar EvidenceExport field (keyed by
+// its JSON tag) into header, or discards it if the key is unrecognized.
+func decodeHeaderField(dec *json.Decoder, header *EvidenceExport, key string) error {
+	switch key {
+	case "version":
+		return dec.Decode(&header.Version)
+	case "exported_at":
+		return dec.Decode(&header.ExportedAt)
+	case "exported_by":
+		return dec.Decode(&header.ExportedBy)
+	case "chain_hash":
+		return dec.Decode(&header.ChainHash)
+	case "blob_count":
+		return dec.Decode(&header.BlobCount)
+	case "first_blob_hash":
+		return dec.Decode(&header.FirstBlobHash)
+	case "last_blob_hash":
+		return dec.Decode(&header.LastBlobHash)
+	case "time_range":
+		return dec.Decode(&header.TimeRange)
+	case "signature":
+		return dec.Decode(&header.Signature)
+	case "signer_key_id":
+		return dec.Decode(&header.SignerKeyID)
+	case "signature_algorithm":
+		return dec.Decode(&header.SignatureAlgorithm)
+	default:
+		var discard interface{}
+		return dec.Decode(&discard)
+	}
+}
+
+// stopSignal lets --fail-fast propagate an abort from the hash/chain
+// verification goroutine in streamVerifyBlobs back to the JSON decode
+// goroutine in streamExportHeader, so a fail-fast run stops reading the
+// file once it has its answer instead of silently decoding every remaining
+// blob anyway.
+type stopSignal struct {
+	ch        chan struct{}
+	once      sync.Once
+	requested int32
+}
+
+func newStopSignal() *stopSignal {
+	return &stopSignal{ch: make(chan struct{})}
+}
+
+// Request aborts the decode; safe to call more than once or concurrently.
+func (s *stopSignal) Request() {
+	s.once.Do(func() {
+		atomic.StoreInt32(&s.requested, 1)
+		close(s.ch)
+	})
+}
+
+// Done reports the abort as a closed channel, so it can be used directly in
+// a select alongside channel sends/receives.
+func (s *stopSignal) Done() <-chan struct{} {
+	return s.ch
+}
+
+// Requested reports whether Request was ever called.
+func (s *stopSignal) Requested() bool {
+	return atomic.LoadInt32(&s.requested) != 0
+}
+
+// errFailFastAbort is what streamExportHeader's decode goroutine reports on
+// decodeErrCh when it abandons the file walk because stop fired, as opposed
+// to a genuine JSON error - callers need to tell "stopped early, header may
+// be incomplete" apart from "stop fired too late to matter, decode had
+// already reached the end of the file on its own".
+var errFailFastAbort = errors.New("fail-fast: file walk aborted before reaching end of export")
+
+// streamExportHeader reads the EvidenceExport's scalar header fields
+// synchronously via Token(), then - once it reaches the "blobs" key - hands
+// off to a goroutine that streams each array element through Decode one at a
+// time into a bounded channel. This keeps memory use O(channel capacity)
+// regardless of export size instead of buffering the whole file. Since
+// fields like "signature" can appear after "blobs" in the JSON, the handoff
+// goroutine keeps filling the same header (passed by pointer) once the array
+// closes, and signals completion by closing errCh - callers that wait on
+// errCh before reading header are guaranteed to see every field.
+//
+// If stop fires while blobs are still being decoded, the goroutine abandons
+// the rest of the file immediately rather than decoding the remainder: a
+// fail-fast caller gets a bounded file walk, not just bounded hashing. The
+// cost is that any header field serialized after "blobs" (e.g. a trailing
+// signature block) won't have been reached yet, so it's left zero-valued.
+func streamExportHeader(dec *json.Decoder, stop *stopSignal) (*EvidenceExport, <-chan NetFlowBlob, <-chan error) {
+	header := &EvidenceExport{}
+	blobsCh := make(chan NetFlowBlob, 256)
+	errCh := make(chan error, 1)
+
+	fail := func(err error) (*EvidenceExport, <-chan NetFlowBlob, <-chan error) {
+		close(blobsCh)
+		errCh <- err
+		close(errCh)
+		return header, blobsCh, errCh
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return fail(err)
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return fail(fmt.Errorf("expected top-level JSON object"))
+	}
+
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return fail(err)
+		}
+		key, _ := keyTok.(string)
+
+		if key != "blobs" {
+			if err := decodeHeaderField(dec, header, key); err != nil {
+				return fail(err)
+			}
+			continue
+		}
+
+		arrTok, err := dec.Token()
+		if err != nil {
+			return fail(err)
+		}
+		if d, ok := arrTok.(json.Delim); !ok || d != '[' {
+			return fail(fmt.Errorf("expected \"blobs\" array"))
+		}
+		go func() {
+			defer close(blobsCh)
+			defer close(errCh)
+			for dec.More() {
+				select {
+				case <-stop.Done():
+					errCh <- errFailFastAbort
+					return
+				default:
+				}
+				var blob NetFlowBlob
+				if err := dec.Decode(&blob); err != nil {
+					errCh <- err
+					return
+				}
+				select {
+				case blobsCh <- blob:
+				case <-stop.Done():
+					errCh <- errFailFastAbort
+					return
+				}
+			}
+			if _, err := dec.Token(); err != nil { // closing ']'
+				errCh <- err
+				return
+			}
+			// Trailing fields (e.g. a "signature" block serialized after
+			// "blobs") still need to land in the same header.
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					errCh <- err
+					return
+				}
+				key, _ := keyTok.(string)
+				if err := decodeHeaderField(dec, header, key); err != nil {
+					errCh <- err
+					return
+				}
+			}
+		}()
+		return header, blobsCh, errCh
+	}
+
+	return fail(fmt.Errorf("export has no \"blobs\" array"))
+}
+
+// verifySummary is the reassembled result of streamVerifyBlobs.
+type verifySummary struct {
+	blobsVerified  int
+	blobHashErrors int
+	chainErrors    int
+	chainHasher    hash.Hash
+}
+
+// blobVerifyResult is what each hashing worker reports back for a blob.
+type blobVerifyResult struct {
+	index        int
+	blob         NetFlowBlob
+	hashInput    string
+	computedHash string
+}
+
+// streamVerifyBlobs fans blobsCh out across opts.workers goroutines that
+// recompute each blob's SHA256 in parallel, then reassembles the results in
+// index order on a single ordering goroutine: that's where PreviousHash
+// linkage is enforced and every blob.Hash is folded into the running
+// chain-hash SHA256, so the chain hash comes out identical to the serial
+// algorithm regardless of worker completion order.
+func streamVerifyBlobs(blobsCh <-chan NetFlowBlob, opts verifyOptions, stop *stopSignal) verifySummary {
+	type indexedBlob struct {
+		index int
+		blob  NetFlowBlob
+	}
+
+	jobs := make(chan indexedBlob, opts.workers*2)
+	results := make(chan blobVerifyResult, opts.workers*2)
+
+	// Producer: tag each streamed blob with its index. Once --fail-fast
+	// calls stop.Request(), this stops draining blobsCh entirely instead of
+	// discarding the rest - the decode goroutine feeding it (in
+	// streamExportHeader) is watching the same signal and abandons the file
+	// walk too, so hashing and decoding both stop together.
+	go func() {
+		defer close(jobs)
+		i := 0
+		for {
+			select {
+			case <-stop.Done():
+				return
+			case blob, ok := <-blobsCh:
+				if !ok {
+					return
+				}
+				jobs <- indexedBlob{index: i, blob: blob}
+				i++
+			}
+		}
+	}()
+
+	// Worker pool: recompute SHA256 in parallel.
+	var workerWG sync.WaitGroup
+	for w := 0; w < opts.workers; w++ {
+		workerWG.Add(1)
+		go func() {
+			defer workerWG.Done()
+			for job := range jobs {
+				hashInput := blobHashInput(job.blob)
+				computedHash := fmt.Sprintf("%x", sha256.Sum256([]byte(hashInput)))
+				results <- blobVerifyResult{index: job.index, blob: job.blob, hashInput: hashInput, computedHash: computedHash}
+			}
+		}()
+	}
+	go func() {
+		workerWG.Wait()
+		close(results)
+	}()
+
+	// Ordering goroutine: reassemble by index, check linkage, fold chain hash.
+	summary := verifySummary{chainHasher: sha256.New()}
+	pending := make(map[int]blobVerifyResult)
+	next := 0
+	var prevBlob *NetFlowBlob
+
+	applyInOrder := func(r blobVerifyResult) {
+		summary.blobsVerified++
+
+		if r.computedHash != r.blob.Hash {
+			summary.blobHashErrors++
+			if summary.blobHashErrors <= 3 {
+				fmt.Printf("  %s✗%s Blob %d hash mismatch:\n", colorRed, colorReset, r.index+1)
+				fmt.Printf("      Expected: %s\n", r.blob.Hash[:32]+"...")
+				fmt.Printf("      Computed: %s\n", r.computedHash[:32]+"...")
+			}
+			if opts.tryRepair {
+				if finding := repairBlob(r.blob, r.hashInput, opts.repairByte, opts.parallel); finding != nil {
+					fmt.Printf("      %s↻ repaired%s via %s flip at offset %d (field %s): %q -> %q\n",
+						colorGreen, colorReset, finding.Method, finding.Offset, finding.Field, finding.Original, finding.Repaired)
+				} else {
+					fmt.Printf("      %s⚠ unrecoverable%s - no single-bit/byte flip reproduces the stored hash (tampering, not bit rot)\n", colorRed, colorReset)
+				}
+			}
+			if opts.failFast {
+				stop.Request()
+			}
+		}
+
+		if prevBlob != nil && r.blob.PreviousHash != prevBlob.Hash {
+			summary.chainErrors++
+			if summary.chainErrors <= 3 {
+				fmt.Printf("  %s✗%s Blob %d chain break:\n", colorRed, colorReset, r.index+1)
+				fmt.Printf("      Expected prev: %s\n", prevBlob.Hash[:32]+"...")
+				fmt.Printf("      Actual prev:   %s\n", r.blob.PreviousHash[:32]+"...")
+			}
+			if opts.failFast {
+				stop.Request()
+			}
+		}
+
+		summary.chainHasher.Write([]byte(r.blob.Hash))
+		prevBlob = &r.blob
+
+		if opts.progress && summary.blobsVerified%1000 == 0 {
+			fmt.Printf("      %d verified...\n", summary.blobsVerified)
+		}
+	}
+
+	for r := range results {
+		pending[r.index] = r
+		for {
+			ready, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			applyInOrder(ready)
+			next++
+		}
+	}
+
+	return summary
+}
+
+func printVerificationResult(blobErrors, chainErrors int, chainHashMatch bool, chainHash string, blobsVerified int, sig signatureCheck, overallOK bool) {
 	fmt.Printf("%s════════════════════════════════════════════════════════════════%s\n", colorCyan, colorReset)
 	fmt.Printf("%s                    VERIFICATION RESULT%s\n", colorBold, colorReset)
 	fmt.Printf("%s════════════════════════════════════════════════════════════════%s\n\n", colorCyan, colorReset)
 
-	if blobErrors == 0 && chainErrors == 0 && chainHashMatch {
+	signedNote := "hash-valid but unsigned"
+	switch {
+	case sig.present && sig.verified:
+		signedNote = fmt.Sprintf("hash-valid and signed by %s", sig.signerKeyID)
+	case sig.present && sig.attempted && !sig.verified:
+		signedNote = fmt.Sprintf("hash-valid but signature NOT verified (%s)", sig.errMsg)
+	case sig.present:
+		signedNote = fmt.Sprintf("hash-valid, signed by %s (unverified - no --trust-root given)", orUnknown(sig.signerKeyID))
+	}
+
+	chainIntact := blobErrors == 0 && chainErrors == 0 && chainHashMatch
+
+	switch {
+	case chainIntact && overallOK:
 		// SUCCESS
 		fmt.Printf("  %s%s╔══════════════════════════════════════════════════════════╗%s\n", colorBold, colorGreen, colorReset)
 		fmt.Printf("  %s%s║                                                          ║%s\n", colorBold, colorGreen, colorReset)
@@ -231,13 +708,38 @@ func printVerificationResult(blobErrors, chainErrors int, chainHashMatch bool, e
 		fmt.Printf("  %s%s║                                                          ║%s\n", colorBold, colorGreen, colorReset)
 		fmt.Printf("  %s%s╚══════════════════════════════════════════════════════════╝%s\n\n", colorBold, colorGreen, colorReset)
 
-		fmt.Printf("  %sChain Hash:%s    %s\n", colorBold, colorReset, export.ChainHash)
-		fmt.Printf("  %sBlobs Verified:%s %d\n", colorBold, colorReset, len(export.Blobs))
+		fmt.Printf("  %sChain Hash:%s    %s\n", colorBold, colorReset, chainHash)
+		fmt.Printf("  %sBlobs Verified:%s %d\n", colorBold, colorReset, blobsVerified)
+		fmt.Printf("  %sSignature:%s     %s\n", colorBold, colorReset, signedNote)
 		fmt.Printf("  %sVerified At:%s   %s\n\n", colorBold, colorReset, time.Now().Format(time.RFC3339))
 
 		fmt.Printf("  The evidence chain has not been tampered with.\n")
 		fmt.Printf("  All cryptographic hashes match the expected values.\n\n")
-	} else {
+
+	case chainIntact && !overallOK:
+		// Every blob hash, chain link, and chain hash checks out - the only
+		// reason this isn't a clean pass is that --trust-root couldn't
+		// verify the signature. That's a missing/untrusted signer, not
+		// evidence of tampering, so it gets its own (amber, not red) banner.
+		fmt.Printf("  %s%s╔══════════════════════════════════════════════════════════╗%s\n", colorBold, colorYellow, colorReset)
+		fmt.Printf("  %s%s║                                                          ║%s\n", colorBold, colorYellow, colorReset)
+		fmt.Printf("  %s%s║   ✗ UNVERIFIED - SIGNATURE NOT TRUSTED                  ║%s\n", colorBold, colorYellow, colorReset)
+		fmt.Printf("  %s%s║                                                          ║%s\n", colorBold, colorYellow, colorReset)
+		fmt.Printf("  %s%s╚══════════════════════════════════════════════════════════╝%s\n\n", colorBold, colorYellow, colorReset)
+
+		fmt.Printf("  %sChain Hash:%s    %s\n", colorBold, colorReset, chainHash)
+		fmt.Printf("  %sBlobs Verified:%s %d\n", colorBold, colorReset, blobsVerified)
+		fmt.Printf("  %sSignature:%s     %s\n", colorBold, colorReset, signedNote)
+		fmt.Printf("  %sVerified At:%s   %s\n\n", colorBold, colorReset, time.Now().Format(time.RFC3339))
+
+		fmt.Printf("  %s⚠ WARNING: This evidence chain is unsigned or its signer is not trusted.%s\n", colorYellow, colorReset)
+		fmt.Printf("  All blob hashes and chain linkage are intact - the data has not\n")
+		fmt.Printf("  been tampered with. It just isn't backed by a signature from a\n")
+		fmt.Printf("  key in --trust-root, so its origin can't be confirmed.\n\n")
+
+		os.Exit(1)
+
+	default:
 		// FAILURE
 		fmt.Printf("  %s%s╔══════════════════════════════════════════════════════════╗%s\n", colorBold, colorRed, colorReset)
 		fmt.Printf("  %s%s║                                                          ║%s\n", colorBold, colorRed, colorReset)
@@ -245,11 +747,12 @@ func printVerificationResult(blobErrors, chainErrors int, chainHashMatch bool, e
 		fmt.Printf("  %s%s║                                                          ║%s\n", colorBold, colorRed, colorReset)
 		fmt.Printf("  %s%s╚══════════════════════════════════════════════════════════╝%s\n\n", colorBold, colorRed, colorReset)
 
-		fmt.Printf("  %sStored Chain Hash:%s   %s\n", colorBold, colorReset, export.ChainHash)
-		fmt.Printf("  %sBlobs Checked:%s       %d\n", colorBold, colorReset, len(export.Blobs))
+		fmt.Printf("  %sStored Chain Hash:%s   %s\n", colorBold, colorReset, chainHash)
+		fmt.Printf("  %sBlobs Checked:%s       %d\n", colorBold, colorReset, blobsVerified)
 		fmt.Printf("  %sHash Errors:%s         %d\n", colorBold, colorReset, blobErrors)
 		fmt.Printf("  %sChain Breaks:%s        %d\n", colorBold, colorReset, chainErrors)
 		fmt.Printf("  %sChain Hash Match:%s    %v\n", colorBold, colorReset, chainHashMatch)
+		fmt.Printf("  %sSignature:%s           %s\n", colorBold, colorReset, signedNote)
 		fmt.Printf("  %sVerified At:%s         %s\n\n", colorBold, colorReset, time.Now().Format(time.RFC3339))
 
 		fmt.Printf("  %s⚠ WARNING: This evidence chain has been modified!%s\n", colorRed, colorReset)
@@ -265,3 +768,855 @@ func printVerificationFailed() {
 	fmt.Printf("  %s%s✗ VERIFICATION FAILED - Unable to process evidence file%s\n", colorBold, colorRed, colorReset)
 	fmt.Printf("%s════════════════════════════════════════════════════════════════%s\n\n", colorRed, colorReset)
 }
+
+// blobHashInput rebuilds the canonical hash-input string for a blob, in the
+// same field order the server uses when it computes blob.Hash.
+func blobHashInput(blob NetFlowBlob) string {
+	return fmt.Sprintf("%s|%s|%d|%d|%s|%d|%d|%d|%s",
+		blob.Record.SrcIP, blob.Record.DstIP, blob.Record.SrcPort, blob.Record.DstPort,
+		blob.Record.Protocol, blob.Record.Timestamp, blob.Record.BytesSent, blob.Record.PacketCount,
+		blob.PreviousHash)
+}
+
+// hashInputFieldBounds returns the [start, end) byte ranges of each "|"
+// delimited field in a canonical hash-input string, alongside the field's
+// NetFlowRecord name (or "PreviousHash" for the trailing segment).
+func hashInputFieldBounds(hashInput string) ([]int, []int, []string) {
+	names := []string{"SrcIP", "DstIP", "SrcPort", "DstPort", "Protocol", "Timestamp", "BytesSent", "PacketCount", "PreviousHash"}
+	parts := strings.Split(hashInput, "|")
+	starts := make([]int, len(parts))
+	ends := make([]int, len(parts))
+	pos := 0
+	for i, p := range parts {
+		starts[i] = pos
+		ends[i] = pos + len(p)
+		pos = ends[i] + 1 // account for the "|" separator
+	}
+	return starts, ends, names
+}
+
+// fieldForOffset maps a byte offset within a canonical hash-input string back
+// to the NetFlowRecord (or chain-link) field it belongs to.
+func fieldForOffset(hashInput string, offset int) string {
+	starts, ends, names := hashInputFieldBounds(hashInput)
+	for i := range starts {
+		if offset >= starts[i] && offset < ends[i] {
+			if i < len(names) {
+				return names[i]
+			}
+			return fmt.Sprintf("field[%d]", i)
+		}
+	}
+	return "separator"
+}
+
+// RepairFinding describes a single-bit or single-byte flip that reproduces a
+// blob's stored hash from its (corrupted) canonical hash-input string.
+type RepairFinding struct {
+	BlobID   string `json:"blob_id"`
+	Method   string `json:"method"` // "bit" or "byte"
+	Offset   int    `json:"offset"`
+	Field    string `json:"field"`
+	Original string `json:"original"`
+	Repaired string `json:"repaired"`
+}
+
+// repairBlob exhaustively flips every single bit (and, if tryByte is set,
+// every single byte value 0-255) of hashInput, looking for a candidate that
+// recomputes to blob.Hash. A pool of `parallel` workers splits the byte
+// offsets among themselves; the first match wins.
+func repairBlob(blob NetFlowBlob, hashInput string, tryByte bool, parallel int) *RepairFinding {
+	target := blob.Hash
+	raw := []byte(hashInput)
+
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	var (
+		mu    sync.Mutex
+		found *RepairFinding
+		wg    sync.WaitGroup
+	)
+
+	tryOffset := func(offset int) *RepairFinding {
+		original := raw[offset]
+
+		// Single-bit flips.
+		for bit := 0; bit < 8; bit++ {
+			candidate := make([]byte, len(raw))
+			copy(candidate, raw)
+			candidate[offset] ^= 1 << uint(bit)
+			if fmt.Sprintf("%x", sha256.Sum256(candidate)) == target {
+				return &RepairFinding{
+					BlobID:   blob.BlobID,
+					Method:   "bit",
+					Offset:   offset,
+					Field:    fieldForOffset(hashInput, offset),
+					Original: string(original),
+					Repaired: string(candidate[offset]),
+				}
+			}
+		}
+
+		// Single-byte flips (0-255), optional: more expensive.
+		if tryByte {
+			candidate := make([]byte, len(raw))
+			copy(candidate, raw)
+			for v := 0; v < 256; v++ {
+				candidate[offset] = byte(v)
+				if fmt.Sprintf("%x", sha256.Sum256(candidate)) == target {
+					return &RepairFinding{
+						BlobID:   blob.BlobID,
+						Method:   "byte",
+						Offset:   offset,
+						Field:    fieldForOffset(hashInput, offset),
+						Original: string(original),
+						Repaired: string(byte(v)),
+					}
+				}
+			}
+		}
+		return nil
+	}
+
+	offsets := make(chan int, len(raw))
+	for i := range raw {
+		offsets <- i
+	}
+	close(offsets)
+
+	for w := 0; w < parallel; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for offset := range offsets {
+				mu.Lock()
+				already := found != nil
+				mu.Unlock()
+				if already {
+					return
+				}
+				if r := tryOffset(offset); r != nil {
+					mu.Lock()
+					if found == nil {
+						found = r
+					}
+					mu.Unlock()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return found
+}
+
+// diffPreviousHash compares a blob's stored PreviousHash against the prior
+// blob's actual Hash byte-for-byte. Unlike repairBlob, no hashing is
+// involved: PreviousHash is a verbatim copy of the prior hash, so a bit flip
+// or byte flip in either string shows up as a direct positional difference.
+// A length mismatch means the two strings aren't a simple bit-rot candidate,
+// so it's reported as unrecoverable rather than diffed.
+func diffPreviousHash(blobID, stored, actual string) []RepairFinding {
+	if len(stored) != len(actual) {
+		return []RepairFinding{{
+			BlobID:   blobID,
+			Method:   "unrecoverable",
+			Offset:   -1,
+			Field:    "PreviousHash",
+			Original: stored,
+			Repaired: actual,
+		}}
+	}
+
+	var findings []RepairFinding
+	for offset := 0; offset < len(stored); offset++ {
+		if stored[offset] == actual[offset] {
+			continue
+		}
+		findings = append(findings, RepairFinding{
+			BlobID:   blobID,
+			Method:   previousHashDiffMethod(stored[offset], actual[offset]),
+			Offset:   offset,
+			Field:    "PreviousHash",
+			Original: string(stored[offset]),
+			Repaired: string(actual[offset]),
+		})
+	}
+	return findings
+}
+
+// previousHashDiffMethod classifies a differing hex digit as a "bit" flip
+// when the two nibbles differ by exactly one bit, or a "byte" flip
+// otherwise.
+func previousHashDiffMethod(original, repaired byte) string {
+	ov, oerr := strconv.ParseUint(string(original), 16, 8)
+	rv, rerr := strconv.ParseUint(string(repaired), 16, 8)
+	if oerr != nil || rerr != nil {
+		return "byte"
+	}
+	xor := ov ^ rv
+	if xor != 0 && xor&(xor-1) == 0 {
+		return "bit"
+	}
+	return "byte"
+}
+
+// RepairReport is the machine-readable (JSON) output of `repair`, suitable
+// for CI to consume.
+type RepairReport struct {
+	ExportFile  string          `json:"export_file"`
+	BlobsTested int             `json:"blobs_tested"`
+	Repaired    []RepairFinding `json:"repaired"`
+	Unrecovered []string        `json:"unrecoverable_blob_ids"`
+	ChainHash   *RepairFinding  `json:"chain_hash_repair,omitempty"`
+	PrevLinks   []RepairFinding `json:"previous_hash_repairs,omitempty"`
+}
+
+// repairEvidence is the entry point for `zantoras-replay repair`. It loads
+// the export, probes every blob whose hash doesn't verify (plus the
+// PreviousHash links and the top-level ChainHash), and writes a JSON report
+// to stdout so CI can consume it.
+func repairEvidence(filePath string, opts verifyOptions) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		fmt.Printf("%s%sError:%s Failed to read file: %v\n", colorBold, colorRed, colorReset, err)
+		os.Exit(1)
+	}
+
+	var export EvidenceExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		fmt.Printf("%s%sError:%s Failed to parse JSON: %v\n", colorBold, colorRed, colorReset, err)
+		os.Exit(1)
+	}
+
+	report := buildRepairReport(export, filePath, opts)
+
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		fmt.Printf("%s%sError:%s Failed to marshal report: %v\n", colorBold, colorRed, colorReset, err)
+		os.Exit(1)
+	}
+	fmt.Println(string(out))
+
+	if len(report.Unrecovered) > 0 {
+		os.Exit(1)
+	}
+}
+
+// buildRepairReport runs the bit/byte-flip and PreviousHash-link probes over
+// an already-parsed export and returns the resulting report; split out from
+// repairEvidence so the probing logic can be exercised without stdout/exit
+// side effects.
+func buildRepairReport(export EvidenceExport, filePath string, opts verifyOptions) RepairReport {
+	parallel := opts.parallel
+	if parallel < 1 {
+		parallel = runtime.NumCPU()
+	}
+
+	report := RepairReport{ExportFile: filePath}
+
+	for i, blob := range export.Blobs {
+		hashInput := blobHashInput(blob)
+		computedHash := fmt.Sprintf("%x", sha256.Sum256([]byte(hashInput)))
+		hashMismatch := computedHash != blob.Hash
+
+		// PreviousHash link probe: PreviousHash is a verbatim copy of the
+		// prior blob's Hash, not a digest of it, so a flipped bit or byte
+		// shows up as a direct byte difference between the two strings -
+		// brute-forcing a SHA256 preimage here would never match.
+		var prevFindings []RepairFinding
+		explainedByPrevLink := false
+		if i > 0 && blob.PreviousHash != export.Blobs[i-1].Hash {
+			prevFindings = diffPreviousHash(blob.BlobID, blob.PreviousHash, export.Blobs[i-1].Hash)
+			if hashMismatch {
+				// If substituting the correct PreviousHash reproduces the
+				// blob's stored Hash, the corruption lives entirely inside
+				// PreviousHash - the prev-link probe above already explains
+				// and reports it, so don't also run the blob-hash probe
+				// (which would emit a second, differently-offset finding
+				// for the same byte, or wrongly call it unrecoverable if
+				// the corruption is more than a single bit).
+				corrected := blob
+				corrected.PreviousHash = export.Blobs[i-1].Hash
+				correctedHash := fmt.Sprintf("%x", sha256.Sum256([]byte(blobHashInput(corrected))))
+				explainedByPrevLink = correctedHash == blob.Hash
+			}
+		}
+		report.PrevLinks = append(report.PrevLinks, prevFindings...)
+
+		if hashMismatch && !explainedByPrevLink {
+			report.BlobsTested++
+
+			if finding := repairBlob(blob, hashInput, opts.repairByte, parallel); finding != nil {
+				report.Repaired = append(report.Repaired, *finding)
+			} else {
+				report.Unrecovered = append(report.Unrecovered, blob.BlobID)
+			}
+		}
+	}
+
+	// Top-level ChainHash probe.
+	var chainHashInput strings.Builder
+	for _, blob := range export.Blobs {
+		chainHashInput.WriteString(blob.Hash)
+	}
+	computedChainHash := fmt.Sprintf("%x", sha256.Sum256([]byte(chainHashInput.String())))
+	if computedChainHash != export.ChainHash {
+		chainBlob := NetFlowBlob{BlobID: "chain_hash", Hash: export.ChainHash}
+		if finding := repairBlob(chainBlob, chainHashInput.String(), opts.repairByte, parallel); finding != nil {
+			finding.Field = "ChainHash"
+			report.ChainHash = finding
+		}
+	}
+
+	return report
+}
+
+// extractOptions selects which blobs `extract` writes out.
+type extractOptions struct {
+	blobID        string
+	hasRange      bool
+	rangeStart    int
+	rangeEnd      int
+	anomaliesOnly bool
+	outDir        string
+}
+
+// parseExtractFlags scans the args following the export path for extract's
+// selection and output flags.
+func parseExtractFlags(args []string) extractOptions {
+	opts := extractOptions{outDir: "."}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case strings.HasPrefix(arg, "--blob-id="):
+			opts.blobID = strings.TrimPrefix(arg, "--blob-id=")
+		case strings.HasPrefix(arg, "--range="):
+			rangeSpec := strings.TrimPrefix(arg, "--range=")
+			parts := strings.SplitN(rangeSpec, ":", 2)
+			if len(parts) == 2 {
+				start, errStart := strconv.Atoi(parts[0])
+				end, errEnd := strconv.Atoi(parts[1])
+				if errStart == nil && errEnd == nil {
+					opts.hasRange = true
+					opts.rangeStart = start
+					opts.rangeEnd = end
+				}
+			}
+		case arg == "--anomalies-only":
+			opts.anomaliesOnly = true
+		case arg == "--out":
+			if i+1 < len(args) {
+				i++
+				opts.outDir = args[i]
+			}
+		case strings.HasPrefix(arg, "--out="):
+			opts.outDir = strings.TrimPrefix(arg, "--out=")
+		}
+	}
+	return opts
+}
+
+// blobManifestEntry is one row of extract's manifest.json.
+type blobManifestEntry struct {
+	Index        int    `json:"index"`
+	BlobID       string `json:"blob_id"`
+	File         string `json:"file"`
+	ExpectedHash string `json:"expected_hash"`
+	ComputedHash string `json:"computed_hash"`
+	HashValid    bool   `json:"hash_valid"`
+	ChainValid   bool   `json:"chain_valid"`
+	ChainNote    string `json:"chain_note,omitempty"`
+	IsAnomaly    bool   `json:"is_anomaly"`
+}
+
+// extractEvidence implements `zantoras-replay extract`: it selects blobs by
+// --blob-id, --range, or --anomalies-only (default: all blobs), writes each
+// as its own JSON file under --out, and writes a manifest.json alongside
+// them recording expected/computed hash and chain linkage status.
+func extractEvidence(filePath string, opts extractOptions) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		fmt.Printf("%s%sError:%s Failed to read file: %v\n", colorBold, colorRed, colorReset, err)
+		os.Exit(1)
+	}
+
+	var export EvidenceExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		fmt.Printf("%s%sError:%s Failed to parse JSON: %v\n", colorBold, colorRed, colorReset, err)
+		os.Exit(1)
+	}
+
+	if err := os.MkdirAll(opts.outDir, 0o755); err != nil {
+		fmt.Printf("%s%sError:%s Failed to create output directory: %v\n", colorBold, colorRed, colorReset, err)
+		os.Exit(1)
+	}
+
+	var manifest []blobManifestEntry
+
+	for i, blob := range export.Blobs {
+		if opts.blobID != "" && blob.BlobID != opts.blobID {
+			continue
+		}
+		if opts.hasRange && (i < opts.rangeStart || i >= opts.rangeEnd) {
+			continue
+		}
+		if opts.anomaliesOnly && !blob.IsAnomaly {
+			continue
+		}
+
+		computedHash := fmt.Sprintf("%x", sha256.Sum256([]byte(blobHashInput(blob))))
+		chainValid := true
+		chainNote := ""
+		if i == 0 {
+			chainNote = "first blob in chain"
+		} else if blob.PreviousHash != export.Blobs[i-1].Hash {
+			chainValid = false
+			chainNote = "previous_hash does not match preceding blob's hash"
+		}
+
+		fileName := fmt.Sprintf("blob-%04d-%s.json", i, blob.BlobID)
+		blobPath := filepath.Join(opts.outDir, fileName)
+		blobJSON, err := json.MarshalIndent(blob, "", "  ")
+		if err != nil {
+			fmt.Printf("%s%sError:%s Failed to marshal blob %s: %v\n", colorBold, colorRed, colorReset, blob.BlobID, err)
+			os.Exit(1)
+		}
+		if err := os.WriteFile(blobPath, blobJSON, 0o644); err != nil {
+			fmt.Printf("%s%sError:%s Failed to write %s: %v\n", colorBold, colorRed, colorReset, blobPath, err)
+			os.Exit(1)
+		}
+
+		manifest = append(manifest, blobManifestEntry{
+			Index:        i,
+			BlobID:       blob.BlobID,
+			File:         fileName,
+			ExpectedHash: blob.Hash,
+			ComputedHash: computedHash,
+			HashValid:    computedHash == blob.Hash,
+			ChainValid:   chainValid,
+			ChainNote:    chainNote,
+			IsAnomaly:    blob.IsAnomaly,
+		})
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		fmt.Printf("%s%sError:%s Failed to marshal manifest: %v\n", colorBold, colorRed, colorReset, err)
+		os.Exit(1)
+	}
+	manifestPath := filepath.Join(opts.outDir, "manifest.json")
+	if err := os.WriteFile(manifestPath, manifestJSON, 0o644); err != nil {
+		fmt.Printf("%s%sError:%s Failed to write manifest: %v\n", colorBold, colorRed, colorReset, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s✓%s Extracted %d blob(s) to %s (manifest.json included)\n", colorGreen, colorReset, len(manifest), opts.outDir)
+}
+
+// dumpEvidence implements `zantoras-replay dump`: it streams the export's
+// records/blobs/chain-links (or all three) as NDJSON on stdout, one JSON
+// object per line, so auditors can pipe verified data into jq, Zeek, or a
+// SIEM without re-shipping the entire export.
+func dumpEvidence(filePath string, mode string) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		fmt.Printf("%s%sError:%s Failed to read file: %v\n", colorBold, colorRed, colorReset, err)
+		os.Exit(1)
+	}
+
+	var export EvidenceExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		fmt.Printf("%s%sError:%s Failed to parse JSON: %v\n", colorBold, colorRed, colorReset, err)
+		os.Exit(1)
+	}
+
+	switch mode {
+	case "records":
+		for _, blob := range export.Blobs {
+			dumpLine(blob.Record)
+		}
+	case "blobs":
+		for _, blob := range export.Blobs {
+			dumpLine(blob)
+		}
+	case "chain":
+		for i, blob := range export.Blobs {
+			dumpLine(map[string]interface{}{
+				"index":         i,
+				"blob_id":       blob.BlobID,
+				"hash":          blob.Hash,
+				"previous_hash": blob.PreviousHash,
+				"linked":        i == 0 || blob.PreviousHash == export.Blobs[i-1].Hash,
+			})
+		}
+	case "all":
+		for i, blob := range export.Blobs {
+			dumpLine(map[string]interface{}{
+				"index":   i,
+				"blob_id": blob.BlobID,
+				"blob":    blob,
+				"record":  blob.Record,
+				"linked":  i == 0 || blob.PreviousHash == export.Blobs[i-1].Hash,
+			})
+		}
+	default:
+		fmt.Printf("%s%sError:%s Unknown dump mode '%s' (want records|blobs|chain|all)\n", colorBold, colorRed, colorReset, mode)
+		os.Exit(1)
+	}
+}
+
+// dumpLine marshals v as a single compact JSON line (NDJSON).
+func dumpLine(v interface{}) {
+	line, err := json.Marshal(v)
+	if err != nil {
+		fmt.Printf("%s%sError:%s Failed to marshal NDJSON line: %v\n", colorBold, colorRed, colorReset, err)
+		os.Exit(1)
+	}
+	fmt.Println(string(line))
+}
+
+// TrustedKey is one entry of the local Ed25519 trust store.
+type TrustedKey struct {
+	KeyID     string `json:"key_id"`
+	PublicKey string `json:"public_key"` // hex-encoded, 32 raw bytes
+}
+
+// signatureCheck is the result of checkSignature, consumed by the final
+// verdict banner to distinguish "hash-valid but unsigned" from "hash-valid
+// and signed by <keyid>".
+type signatureCheck struct {
+	present     bool
+	attempted   bool // a --trust-root was given, so cryptographic verification was attempted
+	verified    bool
+	signerKeyID string
+	algorithm   string
+	errMsg      string
+}
+
+// decodeKeyMaterial accepts either hex or base64 encoded bytes, trimming
+// surrounding whitespace first - sidecar .sig files and pubkey files in this
+// tool may use either.
+func decodeKeyMaterial(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if b, err := hex.DecodeString(s); err == nil {
+		return b, nil
+	}
+	if b, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+	return nil, fmt.Errorf("not valid hex or base64")
+}
+
+// canonicalizeExport produces the stable byte representation of an export
+// that was signed: the full decoded export with only the "signature" field
+// itself stripped (SignerKeyID/SignatureAlgorithm are part of what's signed,
+// since they attest to who signed it and how), re-marshaled through
+// encoding/json, whose struct field order and sorted map keys already make
+// this deterministic.
+//
+// This is a Go-specific canonical form, not the original file bytes: it
+// bakes in encoding/json's struct field order, its HTML-escaping of
+// <, >, and &, and its map-key sorting. A signer that signs over the raw
+// export bytes (e.g. a cosign-style detached signature over the file as
+// written) will never verify here - producer and verifier both need to
+// canonicalize through this exact function.
+func canonicalizeExport(export EvidenceExport) ([]byte, error) {
+	export.Signature = ""
+	return json.Marshal(export)
+}
+
+// loadTrustRoot reads trusted keys from a single JSON file (an array of
+// TrustedKey) or, if path is a directory, merges every *.json file in it.
+func loadTrustRoot(path string) ([]TrustedKey, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return loadTrustStore(path)
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var all []TrustedKey
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		keys, err := loadTrustStore(filepath.Join(path, e.Name()))
+		if err != nil {
+			continue
+		}
+		all = append(all, keys...)
+	}
+	return all, nil
+}
+
+// checkSignature inspects an export's signature fields (or a sidecar
+// "<export>.sig" file when they're absent) and, if trustRootPath is set,
+// cryptographically verifies it against the trust root.
+func checkSignature(filePath string, header *EvidenceExport, trustRootPath string) signatureCheck {
+	check := signatureCheck{algorithm: header.SignatureAlgorithm, signerKeyID: header.SignerKeyID}
+	if check.algorithm == "" {
+		check.algorithm = "ed25519"
+	}
+
+	sigText := header.Signature
+	if sigText == "" {
+		if data, err := os.ReadFile(filePath + ".sig"); err == nil {
+			sigText = string(data)
+		}
+	}
+	if sigText == "" {
+		check.errMsg = "no signature field or .sig sidecar found"
+		fmt.Printf("  %s○%s No signature present\n\n", colorYellow, colorReset)
+		return check
+	}
+	check.present = true
+
+	if trustRootPath == "" {
+		fmt.Printf("  %s○%s Signature present (signer %s, %s) but not verified - pass --trust-root to verify\n\n",
+			colorYellow, colorReset, orUnknown(check.signerKeyID), check.algorithm)
+		return check
+	}
+	check.attempted = true
+
+	sigBytes, err := decodeKeyMaterial(sigText)
+	if err != nil {
+		check.errMsg = fmt.Sprintf("malformed signature: %v", err)
+		fmt.Printf("  %s✗%s %s\n\n", colorRed, colorReset, check.errMsg)
+		return check
+	}
+
+	trustedKeys, err := loadTrustRoot(trustRootPath)
+	if err != nil {
+		check.errMsg = fmt.Sprintf("failed to load trust root: %v", err)
+		fmt.Printf("  %s✗%s %s\n\n", colorRed, colorReset, check.errMsg)
+		return check
+	}
+
+	// With a signer_key_id we can go straight to the matching key. Without
+	// one - e.g. a sidecar .sig with no export fields to name the signer -
+	// there's nothing to look up, so fall back to trialling every key in
+	// the trust root against the signature (this is why the canonical bytes
+	// are computed before key selection below).
+	var candidates []ed25519.PublicKey
+	var candidateIDs []string
+	if check.signerKeyID != "" {
+		for _, k := range trustedKeys {
+			if k.KeyID == check.signerKeyID {
+				if raw, err := decodeKeyMaterial(k.PublicKey); err == nil && len(raw) == ed25519.PublicKeySize {
+					candidates = append(candidates, ed25519.PublicKey(raw))
+					candidateIDs = append(candidateIDs, k.KeyID)
+				}
+				break
+			}
+		}
+		if len(candidates) == 0 {
+			check.errMsg = fmt.Sprintf("signer key %q is not in the trust root", check.signerKeyID)
+			fmt.Printf("  %s✗%s %s\n\n", colorRed, colorReset, check.errMsg)
+			return check
+		}
+	} else {
+		for _, k := range trustedKeys {
+			if raw, err := decodeKeyMaterial(k.PublicKey); err == nil && len(raw) == ed25519.PublicKeySize {
+				candidates = append(candidates, ed25519.PublicKey(raw))
+				candidateIDs = append(candidateIDs, k.KeyID)
+			}
+		}
+		if len(candidates) == 0 {
+			check.errMsg = "export carries a signature but no signer_key_id, and the trust root has no usable keys to trial"
+			fmt.Printf("  %s✗%s %s\n\n", colorRed, colorReset, check.errMsg)
+			return check
+		}
+	}
+
+	// Re-read the full export to canonicalize it: verifying a signature
+	// needs the exact bytes that were signed, which includes every blob, so
+	// this step trades the streaming pipeline's O(workers) memory bound for
+	// an O(export size) buffer - but only when --trust-root is requested.
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		check.errMsg = fmt.Sprintf("failed to re-read export for signature check: %v", err)
+		fmt.Printf("  %s✗%s %s\n\n", colorRed, colorReset, check.errMsg)
+		return check
+	}
+	var full EvidenceExport
+	if err := json.Unmarshal(data, &full); err != nil {
+		check.errMsg = fmt.Sprintf("failed to parse export for signature check: %v", err)
+		fmt.Printf("  %s✗%s %s\n\n", colorRed, colorReset, check.errMsg)
+		return check
+	}
+	canonical, err := canonicalizeExport(full)
+	if err != nil {
+		check.errMsg = fmt.Sprintf("failed to canonicalize export: %v", err)
+		fmt.Printf("  %s✗%s %s\n\n", colorRed, colorReset, check.errMsg)
+		return check
+	}
+
+	for i, pubKey := range candidates {
+		if ed25519.Verify(pubKey, canonical, sigBytes) {
+			check.verified = true
+			check.signerKeyID = candidateIDs[i]
+			return check
+		}
+	}
+
+	check.errMsg = "signature does not match trusted key"
+	fmt.Printf("  %s✗%s Signature verification FAILED for signer %s\n\n", colorRed, colorReset, orUnknown(check.signerKeyID))
+	return check
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+// printSignatureCheck prints the success line for checkSignature; failure
+// and "unsigned" lines are printed inline by checkSignature itself since
+// they carry check-specific detail.
+func printSignatureCheck(sig signatureCheck) {
+	if sig.present && sig.verified {
+		fmt.Printf("  %s✓%s Signature verified (signer %s, %s)\n\n", colorGreen, colorReset, sig.signerKeyID, sig.algorithm)
+	}
+}
+
+// trustStorePath returns the local trust store location, analogous to
+// ~/.ssh/known_hosts: a single JSON file of TrustedKey entries under the
+// user's home directory.
+func trustStorePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return ".zantoras-trust.json"
+	}
+	return filepath.Join(home, ".zantoras", "trust_store.json")
+}
+
+// loadTrustStore reads a JSON array of TrustedKey from path. A missing file
+// is treated as an empty trust store, not an error.
+func loadTrustStore(path string) ([]TrustedKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var keys []TrustedKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// saveTrustStore writes keys to path as a JSON array, creating parent
+// directories as needed.
+func saveTrustStore(path string, keys []TrustedKey) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// keysFingerprint prints the SHA256 fingerprint of an Ed25519 public key
+// file, colon-grouped for readability (the same convention ssh-keygen -l
+// uses for host key fingerprints).
+func keysFingerprint(pubKeyFile string) {
+	data, err := os.ReadFile(pubKeyFile)
+	if err != nil {
+		fmt.Printf("%s%sError:%s Failed to read %s: %v\n", colorBold, colorRed, colorReset, pubKeyFile, err)
+		os.Exit(1)
+	}
+	raw, err := decodeKeyMaterial(string(data))
+	if err != nil {
+		fmt.Printf("%s%sError:%s %s is not a valid hex/base64 public key: %v\n", colorBold, colorRed, colorReset, pubKeyFile, err)
+		os.Exit(1)
+	}
+	sum := sha256.Sum256(raw)
+	hexSum := fmt.Sprintf("%x", sum)
+	var groups []string
+	for i := 0; i < len(hexSum); i += 2 {
+		groups = append(groups, hexSum[i:i+2])
+	}
+	fmt.Printf("SHA256:%s\n", strings.Join(groups, ":"))
+}
+
+// keysAdd trusts a public key under keyID in the local trust store.
+func keysAdd(keyID, pubKeyFile string) {
+	data, err := os.ReadFile(pubKeyFile)
+	if err != nil {
+		fmt.Printf("%s%sError:%s Failed to read %s: %v\n", colorBold, colorRed, colorReset, pubKeyFile, err)
+		os.Exit(1)
+	}
+	raw, err := decodeKeyMaterial(string(data))
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		fmt.Printf("%s%sError:%s %s is not a valid Ed25519 public key\n", colorBold, colorRed, colorReset, pubKeyFile)
+		os.Exit(1)
+	}
+
+	path := trustStorePath()
+	keys, err := loadTrustStore(path)
+	if err != nil {
+		fmt.Printf("%s%sError:%s Failed to load trust store: %v\n", colorBold, colorRed, colorReset, err)
+		os.Exit(1)
+	}
+
+	replaced := false
+	for i, k := range keys {
+		if k.KeyID == keyID {
+			keys[i].PublicKey = hex.EncodeToString(raw)
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		keys = append(keys, TrustedKey{KeyID: keyID, PublicKey: hex.EncodeToString(raw)})
+	}
+
+	if err := saveTrustStore(path, keys); err != nil {
+		fmt.Printf("%s%sError:%s Failed to save trust store: %v\n", colorBold, colorRed, colorReset, err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s✓%s Trusted %s as %s (%s)\n", colorGreen, colorReset, pubKeyFile, keyID, path)
+}
+
+// keysRemove removes keyID from the local trust store.
+func keysRemove(keyID string) {
+	path := trustStorePath()
+	keys, err := loadTrustStore(path)
+	if err != nil {
+		fmt.Printf("%s%sError:%s Failed to load trust store: %v\n", colorBold, colorRed, colorReset, err)
+		os.Exit(1)
+	}
+
+	kept := keys[:0]
+	removed := false
+	for _, k := range keys {
+		if k.KeyID == keyID {
+			removed = true
+			continue
+		}
+		kept = append(kept, k)
+	}
+	if !removed {
+		fmt.Printf("%s%sError:%s No trusted key with id %s\n", colorBold, colorRed, colorReset, keyID)
+		os.Exit(1)
+	}
+
+	if err := saveTrustStore(path, kept); err != nil {
+		fmt.Printf("%s%sError:%s Failed to save trust store: %v\n", colorBold, colorRed, colorReset, err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s✓%s Removed %s from the trust store (%s)\n", colorGreen, colorReset, keyID, path)
+}